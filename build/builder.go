@@ -3,10 +3,12 @@
 package build
 
 import (
+	"github.com/dominikbraun/espresso/build/urls"
 	"github.com/dominikbraun/espresso/config"
 	"github.com/dominikbraun/espresso/model"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -27,6 +29,7 @@ type builder struct {
 	ctx   Context
 	model *Site
 	mutex *sync.Mutex
+	paths *urls.PathSpec
 }
 
 // newBuilder creates a builder instance that utilizes the build context.
@@ -35,6 +38,7 @@ func newBuilder(ctx Context) *builder {
 		ctx:   ctx,
 		model: newSite(),
 		mutex: &sync.Mutex{},
+		paths: urls.NewPathSpec(ctx.Settings),
 	}
 	return &b
 }
@@ -66,32 +70,33 @@ func (b *builder) buildPage(source []byte, file string, mode registerMode) (*mod
 	// Remove the build path and content dir to get the relative path.
 	relativePath := file[contentDirLen:]
 
-	route := filepath.ToSlash(filepath.Dir(relativePath))
+	// normalizeRoute turns the root's raw "/" into "", matching the key
+	// every other route is registered and looked up under, so a root
+	// content/index.md is recognized as model.KindHome rather than
+	// model.KindSection.
+	route := normalizeRoute(filepath.ToSlash(filepath.Dir(relativePath)))
 	article.ID = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
 
-	page := model.Page{
-		Path: route,
-	}
+	articlePage := model.NewArticlePage(route, article, nil)
 
 	// The user is allowed to provide their own `index.md` file as an
 	// index page. In this case, the article ID equals `index` and the
-	// article will be rendered as the route's index page.
+	// article will be rendered as the route's index page rather than a
+	// sub-path of it, so its target is resolved from the route alone.
 	if article.ID == "index" {
-		b.registerIndexPage(&model.IndexPage{
-			Page:    page,
-			Article: article,
-		})
+		_, permalink := b.paths.IndexTarget(route)
+		articlePage.SetPermalink(permalink)
+
+		indexPage := model.NewIndexPage(route, article, nil)
+		indexPage.SetPermalink(permalink)
+		b.registerIndexPage(indexPage)
 	} else {
-		b.registerPage(&model.ArticlePage{
-			Page:    page,
-			Article: article,
-		})
+		_, permalink := b.paths.PageTarget(route, article)
+		articlePage.SetPermalink(permalink)
+		b.registerPage(articlePage)
 	}
 
-	return &model.ArticlePage{
-		Page:    page,
-		Article: article,
-	}, nil
+	return articlePage, nil
 }
 
 // registerPage registers a page model to the builder's site model.
@@ -142,7 +147,7 @@ func (b *builder) buildNav() error {
 			if len(segments) == 1 {
 				item := model.NavItem{
 					Label:  strings.Title(segments[0]),
-					Target: segments[0],
+					Target: b.paths.RouteTarget(segments[0]),
 				}
 				nav.Items = append(nav.Items, item)
 			}
@@ -153,63 +158,223 @@ func (b *builder) buildNav() error {
 	return nil
 }
 
-// buildListPages attempts to build overview pages for all categories.
-// For each route in the route tree, all articles are added to the
-// routes's list page model.
+// buildListPages attempts to build a section page for every route in
+// the site, including the root, whose section page becomes the site's
+// home page (model.KindHome). A route's section page is its
+// auto-generated ListPage, unless the user already provided an
+// IndexPage for it.
+//
+// If b.ctx.Settings.Paginate is set and a route has more articles than
+// that, the route's full article list is split across multiple
+// ListPage instances: the first page stays at the route itself for
+// backward compatibility, while subsequent pages are registered under
+// synthetic sub-routes like `/blog/page/2`.
 func (b *builder) buildListPages(sortPages bool) error {
-	b.model.
-		WalkRoutes(func(r string, i *RouteInfo) {
-			// Skip routes for which the user has provided an index page.
-			// In this case, the route's ListPage remains nil.
-			if i.IndexPage != nil {
-				return
-			}
-			i.ListPage = &model.ListPage{
-				Page:         model.Page{Path: r},
-				ArticlePages: make([]*model.ArticlePage, len(i.Pages)),
-			}
+	b.model.WalkRoutes(func(r string, i *RouteInfo) {
+		b.buildListPage(r, i, sortPages)
+	})
 
-			if sortPages {
-				sort.Slice(i.Pages, func(a, b int) bool {
-					return i.Pages[a].Article.Date.After(i.Pages[b].Article.Date)
-				})
-			}
+	// The root is built after the walk so that its own pagination
+	// sub-routes (e.g. `page/2`) are not part of the walk's snapshot.
+	b.buildListPage("", b.model.routeFor(""), sortPages)
 
-			for n, page := range i.Pages {
-				if page.Article.Hide {
-					continue
-				}
-				i.ListPage.ArticlePages[n] = page
-			}
+	return nil
+}
+
+// buildListPage builds the ListPage for a single route, unless the user
+// already provided an IndexPage for it, in which case the route's
+// section page remains that IndexPage.
+func (b *builder) buildListPage(r string, i *RouteInfo, sortPages bool) {
+	if i.IndexPage != nil {
+		return
+	}
+
+	// A route that only carries taxonomy pages (e.g. `tags` or
+	// `tags/go`) isn't a section in its own right; it's represented by
+	// its model.TaxonomyPage(s) and surfaced through
+	// Site.TaxonomyPages, not Site.Sections. Without this guard, every
+	// taxonomy route would get an empty, article-less ListPage attached
+	// that shadows the real TaxonomyPage as the route's section page.
+	if len(i.Pages) == 0 && len(i.TaxonomyPages) > 0 {
+		return
+	}
+
+	if sortPages {
+		sort.Slice(i.Pages, func(a, b int) bool {
+			return i.Pages[a].Article.Date.After(i.Pages[b].Article.Date)
 		})
+	}
 
-	return nil
+	visible := make([]*model.ArticlePage, 0, len(i.Pages))
+	for _, page := range i.Pages {
+		if page.Article.Hide {
+			continue
+		}
+		visible = append(visible, page)
+	}
+
+	i.ListPage = b.paginateListPage(r, visible)
+
+	kind := model.KindSection
+	if r == "" {
+		kind = model.KindHome
+	}
+	i.OutputFormats = b.outputFormatsFor(kind)
+}
+
+// outputFormatsFor resolves the model.OutputFormat list a page of the
+// given kind should be rendered to, as configured via
+// config.Settings.Outputs (e.g. `outputs: {section: [html, gemini]}`).
+// A kind without an entry, or with only unrecognized format names, falls
+// back to model.DefaultOutputFormats.
+//
+// Resolving the format *selection* is as far as the build package goes:
+// actually emitting the per-format files (e.g. converting a page's
+// Markdown to gemtext via render/gemini.ConvertMarkdown and writing it
+// under the target directory) is the renderer's job, not the builder's.
+func (b *builder) outputFormatsFor(kind model.PageKind) []model.OutputFormat {
+	names, ok := b.ctx.Settings.Outputs[kind.String()]
+	if !ok {
+		return model.DefaultOutputFormats
+	}
+
+	formats := make([]model.OutputFormat, 0, len(names))
+	for _, name := range names {
+		if format, ok := model.FormatByName(name); ok {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		return model.DefaultOutputFormats
+	}
+
+	return formats
 }
 
 // addArticlePagesToIndexPages adds all built articles to each IndexPage
 // by appending a pointer to each article page in the ArticlePages slice.
+// Just like buildListPages, the result is paginated according to
+// b.ctx.Settings.Paginate; pages beyond the first are registered as
+// plain ListPages since an IndexPage only exists once per route.
 //
-// ToDo: Find a more efficient way for traversing all routes.
+// Site.WalkRoutes deliberately skips the site root, so the root's own
+// IndexPage (a user-provided root `index.md`) is handled via an explicit
+// call first, the same way buildListPages special-cases it.
 func (b *builder) addArticlePagesToIndexPages() error {
-	b.model.
-		WalkRoutes(func(r string, i *RouteInfo) {
-			// Don't walk all routes again if there's no index page.
-			if i.IndexPage == nil {
-				return
-			}
-			b.model.WalkRoutes(func(r2 string, i2 *RouteInfo) {
-				for _, page := range i2.Pages {
-					if page.Article.Hide {
-						continue
-					}
-					i.IndexPage.ArticlePages = append(i.IndexPage.ArticlePages, page)
-				}
-			})
-		})
+	b.addArticlePagesToIndexPage("", b.model.routeFor(""))
+
+	b.model.WalkRoutes(func(r string, i *RouteInfo) {
+		b.addArticlePagesToIndexPage(r, i)
+	})
 
 	return nil
 }
 
+// addArticlePagesToIndexPage adds every visible descendant article to a
+// single route's IndexPage, if it has one.
+func (b *builder) addArticlePagesToIndexPage(r string, i *RouteInfo) {
+	// Don't walk the whole site if there's no index page; only its own
+	// descendants are relevant.
+	if i.IndexPage == nil {
+		return
+	}
+
+	articlePages := make([]*model.ArticlePage, 0)
+	b.model.WalkDescendants(r, func(r2 string, i2 *RouteInfo) {
+		for _, page := range i2.Pages {
+			if page.Article.Hide {
+				continue
+			}
+			articlePages = append(articlePages, page)
+		}
+	})
+
+	firstPage := b.paginateListPage(r, articlePages)
+	i.IndexPage.ArticlePages = firstPage.ArticlePages
+	i.IndexPage.Paginator = firstPage.Paginator
+}
+
+// paginateListPage splits pages into chunks of b.ctx.Settings.Paginate
+// size and registers a ListPage for every chunk beyond the first under
+// a synthetic `route/page/N` sub-route. It returns the ListPage for the
+// first chunk, which the caller is responsible for registering under
+// route itself.
+//
+// If pagination is disabled or unnecessary, paginateListPage returns a
+// single ListPage holding every page and no Paginator.
+func (b *builder) paginateListPage(route string, pages []*model.ArticlePage) *model.ListPage {
+	perPage := b.ctx.Settings.Paginate
+	if perPage <= 0 || len(pages) <= perPage {
+		listPage := model.NewListPage(route, nil)
+		listPage.ArticlePages = pages
+		return listPage
+	}
+
+	totalPages := (len(pages) + perPage - 1) / perPage
+	var firstPage *model.ListPage
+
+	for n := 0; n < totalPages; n++ {
+		start := n * perPage
+		end := start + perPage
+		if end > len(pages) {
+			end = len(pages)
+		}
+
+		pageRoute := route
+		if n > 0 {
+			pageRoute = filepath.Join(route, "page", strconv.Itoa(n+1))
+		}
+
+		listPage := model.NewListPage(pageRoute, nil)
+		listPage.ArticlePages = pages[start:end]
+		listPage.Paginator = b.paginatorFor(route, n+1, totalPages)
+
+		if n == 0 {
+			firstPage = listPage
+		} else {
+			b.registerListPage(pageRoute, listPage)
+		}
+	}
+
+	return firstPage
+}
+
+// paginatorFor builds the model.Paginator for page number current out
+// of total, all belonging to route.
+func (b *builder) paginatorFor(route string, current, total int) *model.Paginator {
+	urlFor := func(n int) string {
+		if n <= 1 {
+			return route
+		}
+		return filepath.Join(route, "page", strconv.Itoa(n))
+	}
+
+	paginator := &model.Paginator{
+		CurrentPage: current,
+		TotalPages:  total,
+		FirstURL:    urlFor(1),
+		LastURL:     urlFor(total),
+	}
+
+	if current > 1 {
+		paginator.PrevURL = urlFor(current - 1)
+	}
+	if current < total {
+		paginator.NextURL = urlFor(current + 1)
+	}
+
+	return paginator
+}
+
+// registerListPage registers a list page to the builder's site model.
+//
+// registerListPage is safe for concurrent invocation.
+func (b *builder) registerListPage(route string, listPage *model.ListPage) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.model.registerListPage(route, listPage)
+}
+
 // buildRelated attempts to store all related articles for each article
 // in the page tree. Storing a pointer to these related articles allows
 // the user to access the fields of each article in his templates.
@@ -218,14 +383,11 @@ func (b *builder) buildRelated() error {
 		WalkRoutes(func(r string, i *RouteInfo) {
 			for _, p := range i.Pages {
 				for _, link := range p.Article.Related {
-					// A `link` consists of an Espresso path like `/coffee`
-					// and an article ID like `coffee-roasting-basics`. These
-					// components are split here to resolve the path.
-					path := link[:strings.LastIndex(link, "/")]
-					id := link[len(path)+1:]
-
-					// Load the page and its article by resolving its path.
-					page, _ := b.model.resolvePath(path, id)
+					// A `link` consists of an Espresso path like
+					// `/coffee` and an article ID like
+					// `coffee-roasting-basics` joined together, e.g.
+					// `/coffee/coffee-roasting-basics`.
+					page, _ := b.model.resolveLink(link)
 					p.Article.RelatedPages = append(p.Article.RelatedPages, page)
 				}
 			}
@@ -234,6 +396,203 @@ func (b *builder) buildRelated() error {
 	return nil
 }
 
+// mutableParent is implemented by every concrete page type and lets
+// buildPageHierarchy set a page's parent after construction, without
+// widening the public model.Page interface with a setter.
+type mutableParent interface {
+	SetParent(model.Page)
+}
+
+// buildPageHierarchy resolves the Parent() of every page in the site: a
+// regular article's parent is its own route's section page (its
+// IndexPage or ListPage); a section page's parent is its parent
+// route's section page, all the way up to the home page, whose parent
+// stays nil; taxonomy pages are parented the same way as section pages,
+// to their parent route's section page, falling back to the taxonomy's
+// kind page for a parent route that holds nothing else (e.g. `tags` for
+// the term page `tags/go`). A pagination sub-route
+// like `blog/page/2` parents to its owning section (`blog`) rather
+// than its literal parent directory, which was never registered as a
+// route of its own.
+//
+// buildPageHierarchy must be called after buildListPages,
+// addArticlePagesToIndexPages and buildTaxonomies have all finished.
+func (b *builder) buildPageHierarchy() error {
+	var homePage model.Page
+	if home, ok := b.model.routeInfo(""); ok {
+		homePage = home.sectionPage()
+		for _, page := range home.Pages {
+			page.SetParent(homePage)
+		}
+	}
+
+	b.model.WalkRoutes(func(r string, i *RouteInfo) {
+		parentRoute := parentRouteFor(r)
+
+		section := homePage
+		if parent, ok := b.model.routeInfo(parentRoute); ok {
+			if parentSection := owningPage(parent); parentSection != nil {
+				section = parentSection
+			}
+		}
+
+		ownSection := i.sectionPage()
+		if ownSection == nil {
+			ownSection = section
+		}
+		for _, page := range i.Pages {
+			page.SetParent(ownSection)
+		}
+		for _, taxonomyPage := range i.TaxonomyPages {
+			taxonomyPage.SetParent(section)
+		}
+		if sp, ok := i.sectionPage().(mutableParent); ok {
+			sp.SetParent(section)
+		}
+	})
+
+	return nil
+}
+
+// parentRouteFor returns the route that owns r for hierarchy purposes.
+// For a synthetic pagination sub-route like `blog/page/2`, registered
+// by paginateListPage, that's `blog` itself rather than the literal
+// filepath.Dir, which would be the never-registered `blog/page`. Every
+// other route's owner is simply its parent directory.
+func parentRouteFor(r string) string {
+	if owner, ok := paginationOwner(r); ok {
+		return owner
+	}
+
+	parentRoute := filepath.ToSlash(filepath.Dir(r))
+	if parentRoute == "." {
+		parentRoute = ""
+	}
+	return parentRoute
+}
+
+// paginationOwner returns the route a synthetic `<route>/page/<n>`
+// sub-route belongs to, and whether r is one.
+func paginationOwner(r string) (string, bool) {
+	segments := strings.Split(r, "/")
+	if len(segments) < 2 || segments[len(segments)-2] != "page" {
+		return "", false
+	}
+	if _, err := strconv.Atoi(segments[len(segments)-1]); err != nil {
+		return "", false
+	}
+	return strings.Join(segments[:len(segments)-2], "/"), true
+}
+
+// owningPage returns the page that represents route for hierarchy
+// purposes: its sectionPage if it has one, otherwise its taxonomy kind
+// page, if any. Unlike sectionPage, this also recognizes a pure
+// taxonomy route (e.g. `tags`, which never gets a ListPage of its own,
+// see buildListPage) so that its term pages (e.g. `tags/go`) parent to
+// it instead of falling back all the way to the home page.
+func owningPage(i *RouteInfo) model.Page {
+	if page := i.sectionPage(); page != nil {
+		return page
+	}
+	for _, taxonomyPage := range i.TaxonomyPages {
+		if taxonomyPage.Term == "" {
+			return taxonomyPage
+		}
+	}
+	return nil
+}
+
+// buildTaxonomies groups every registered, non-hidden article by each
+// of its taxonomy terms and attaches the resulting term and kind pages
+// to the site model. The taxonomies themselves, and the route each of
+// them lives under, are declared via b.ctx.Settings.Taxonomies (e.g.
+// `taxonomies: {tag: tags, category: categories}`).
+//
+// buildTaxonomies must be called after all buildPage calls have
+// finished.
+func (b *builder) buildTaxonomies() error {
+	taxonomies := b.ctx.Settings.Taxonomies
+	if len(taxonomies) == 0 {
+		return nil
+	}
+
+	// pagesByTerm maps a taxonomy name to its terms, and each term to
+	// the article pages carrying it.
+	pagesByTerm := make(map[string]map[string][]*model.ArticlePage, len(taxonomies))
+	for name := range taxonomies {
+		pagesByTerm[name] = make(map[string][]*model.ArticlePage)
+	}
+
+	b.model.WalkDescendants("", func(r string, i *RouteInfo) {
+		for _, page := range i.Pages {
+			if page.Article.Hide {
+				continue
+			}
+			for name := range taxonomies {
+				for _, term := range page.Article.TaxonomyTerms(name) {
+					slug := slugifyTerm(term)
+					pagesByTerm[name][slug] = append(pagesByTerm[name][slug], page)
+				}
+			}
+		}
+	})
+
+	for name, route := range taxonomies {
+		kindPage := model.NewTaxonomyPage(route, name, "", nil)
+		kindPage.Terms = make([]model.TaxonomyTerm, 0, len(pagesByTerm[name]))
+
+		for term, pages := range pagesByTerm[name] {
+			sort.Slice(pages, func(a, b int) bool {
+				return pages[a].Article.Date.After(pages[b].Article.Date)
+			})
+
+			termRoute := filepath.Join(route, term)
+			termPage := model.NewTaxonomyPage(termRoute, name, term, nil)
+			termPage.ArticlePages = pages
+			b.registerTaxonomyPage(termRoute, termPage)
+
+			kindPage.Terms = append(kindPage.Terms, model.TaxonomyTerm{
+				Term:  term,
+				Path:  termRoute,
+				Count: len(pages),
+			})
+		}
+
+		sort.Slice(kindPage.Terms, func(a, b int) bool {
+			return kindPage.Terms[a].Term < kindPage.Terms[b].Term
+		})
+
+		b.registerTaxonomyPage(route, kindPage)
+	}
+
+	return nil
+}
+
+// registerTaxonomyPage registers a taxonomy page to the builder's site
+// model.
+//
+// registerTaxonomyPage is safe for concurrent invocation.
+func (b *builder) registerTaxonomyPage(route string, taxonomyPage *model.TaxonomyPage) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.model.registerTaxonomyPage(route, taxonomyPage)
+
+	if info, ok := b.model.routeInfo(route); ok {
+		info.OutputFormats = b.outputFormatsFor(taxonomyPage.Kind())
+	}
+}
+
+// slugifyTerm normalizes a taxonomy term into a lowercase, kebab-case
+// slug, e.g. "Hello World" becomes "hello-world". It only trims the
+// term, lowercases it and joins its whitespace-separated words with
+// hyphens; punctuation and other characters, slashes included, are kept
+// as they are.
+func slugifyTerm(term string) string {
+	slug := strings.ToLower(strings.TrimSpace(term))
+	slug = strings.Join(strings.Fields(slug), "-")
+	return slug
+}
+
 // buildFooter attempts to create a model.Footer under consideration of
 // user-defined site settings. It is independent from any site pages.
 func (b *builder) buildFooter() error {
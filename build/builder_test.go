@@ -0,0 +1,209 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dominikbraun/espresso/config"
+	"github.com/dominikbraun/espresso/model"
+)
+
+// newTestArticlePages returns n article pages with distinct IDs, in the
+// order paginateListPage expects them to already be sorted/filtered in.
+func newTestArticlePages(n int) []*model.ArticlePage {
+	pages := make([]*model.ArticlePage, 0, n)
+	for i := 0; i < n; i++ {
+		article := &model.Article{ID: string(rune('a' + i))}
+		pages = append(pages, model.NewArticlePage("blog", article, nil))
+	}
+	return pages
+}
+
+func TestPaginateListPage(t *testing.T) {
+	tests := []struct {
+		name          string
+		paginate      int
+		pageCount     int
+		wantPages     int
+		wantFirstSize int
+	}{
+		{name: "disabled", paginate: 0, pageCount: 5, wantPages: 1, wantFirstSize: 5},
+		{name: "fits on one page", paginate: 10, pageCount: 3, wantPages: 1, wantFirstSize: 3},
+		{name: "splits across pages", paginate: 2, pageCount: 5, wantPages: 3, wantFirstSize: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBuilder(Context{Settings: config.Settings{Paginate: tt.paginate}})
+			pages := newTestArticlePages(tt.pageCount)
+
+			first := b.paginateListPage("blog", pages)
+
+			if len(first.ArticlePages) != tt.wantFirstSize {
+				t.Fatalf("first page size = %d, want %d", len(first.ArticlePages), tt.wantFirstSize)
+			}
+
+			gotPages := 1
+			b.model.WalkRoutes(func(r string, i *RouteInfo) {
+				if i.ListPage != nil && r != "blog" {
+					gotPages++
+				}
+			})
+			if gotPages != tt.wantPages {
+				t.Fatalf("registered page count = %d, want %d", gotPages, tt.wantPages)
+			}
+		})
+	}
+}
+
+// TestBuildListPagesDoesNotRevisitOwnPaginationPages guards against the
+// route tree being mutated while buildListPages is still walking it: a
+// synthetic `blog/page/2` route inserted by paginateListPage must not be
+// visited again in the same walk and overwritten with an empty ListPage.
+func TestBuildListPagesDoesNotRevisitOwnPaginationPages(t *testing.T) {
+	b := newBuilder(Context{Settings: config.Settings{Paginate: 2}})
+
+	for _, page := range newTestArticlePages(5) {
+		b.registerPage(page)
+	}
+
+	if err := b.buildListPages(false); err != nil {
+		t.Fatalf("buildListPages: %v", err)
+	}
+
+	node, ok := b.model.routeInfo("blog/page/2")
+	if !ok || node.ListPage == nil {
+		t.Fatalf("blog/page/2 was not registered with a ListPage")
+	}
+	if len(node.ListPage.ArticlePages) == 0 {
+		t.Fatalf("blog/page/2 was overwritten with an empty ListPage")
+	}
+	if node.ListPage.Paginator == nil {
+		t.Fatalf("blog/page/2 lost its Paginator")
+	}
+}
+
+// TestBuildListPageSkipsTaxonomyOnlyRoutes guards against buildListPage
+// attaching an empty, article-less ListPage to a route that only exists
+// to hold a model.TaxonomyPage (e.g. `tags/go`), which would otherwise
+// shadow the real TaxonomyPage as the route's section page and make it
+// show up as a bogus empty section.
+func TestBuildListPageSkipsTaxonomyOnlyRoutes(t *testing.T) {
+	b := newBuilder(Context{Settings: config.Settings{}})
+
+	termPage := model.NewTaxonomyPage("tags/go", "tag", "go", nil)
+	b.registerTaxonomyPage("tags/go", termPage)
+
+	if err := b.buildListPages(false); err != nil {
+		t.Fatalf("buildListPages: %v", err)
+	}
+
+	node, ok := b.model.routeInfo("tags/go")
+	if !ok {
+		t.Fatalf("tags/go route is missing")
+	}
+	if node.ListPage != nil {
+		t.Fatalf("tags/go got a ListPage, want none")
+	}
+
+	for _, page := range b.model.Sections() {
+		if page.Path() == "tags/go" {
+			t.Fatalf("tags/go appeared as a section, want it only reachable via TaxonomyPages")
+		}
+	}
+}
+
+// TestAddArticlePagesToIndexPagesHandlesRoot guards against the root
+// IndexPage (a user-provided root index.md) being skipped:
+// Site.WalkRoutes deliberately never visits the "" route, so
+// addArticlePagesToIndexPages must handle it via an explicit call of its
+// own, the same way buildListPages does.
+func TestAddArticlePagesToIndexPagesHandlesRoot(t *testing.T) {
+	b := newBuilder(Context{Settings: config.Settings{}})
+
+	homeArticle := &model.Article{ID: "index"}
+	homeIndexPage := model.NewIndexPage("", homeArticle, nil)
+	b.registerIndexPage(homeIndexPage)
+
+	for _, page := range newTestArticlePages(2) {
+		b.registerPage(page)
+	}
+
+	if err := b.addArticlePagesToIndexPages(); err != nil {
+		t.Fatalf("addArticlePagesToIndexPages: %v", err)
+	}
+
+	if len(homeIndexPage.ArticlePages) != 2 {
+		t.Fatalf("home IndexPage.ArticlePages = %d, want 2", len(homeIndexPage.ArticlePages))
+	}
+}
+
+// TestBuildPageHierarchyParentsTermPageToKindPage guards against a
+// taxonomy term page (e.g. `tags/go`) falling back to the home page as
+// its Parent(): its route's owning page is the taxonomy's own kind
+// page (`tags`), which never gets a ListPage of its own.
+func TestBuildPageHierarchyParentsTermPageToKindPage(t *testing.T) {
+	b := newBuilder(Context{Settings: config.Settings{}})
+
+	kindPage := model.NewTaxonomyPage("tags", "tag", "", nil)
+	b.registerTaxonomyPage("tags", kindPage)
+	termPage := model.NewTaxonomyPage("tags/go", "tag", "go", nil)
+	b.registerTaxonomyPage("tags/go", termPage)
+
+	if err := b.buildPageHierarchy(); err != nil {
+		t.Fatalf("buildPageHierarchy: %v", err)
+	}
+
+	if termPage.Parent() != model.Page(kindPage) {
+		t.Fatalf("tags/go parent = %v, want the tags kind page", termPage.Parent())
+	}
+}
+
+// TestBuildPageHierarchyParentsPaginationPageToSection guards against a
+// pagination sub-route like `blog/page/2` resolving its parent via the
+// literal filepath.Dir of its synthetic route (`blog/page`, never
+// registered) instead of the section it actually paginates (`blog`).
+func TestBuildPageHierarchyParentsPaginationPageToSection(t *testing.T) {
+	b := newBuilder(Context{Settings: config.Settings{Paginate: 2}})
+
+	for _, page := range newTestArticlePages(5) {
+		b.registerPage(page)
+	}
+	if err := b.buildListPages(false); err != nil {
+		t.Fatalf("buildListPages: %v", err)
+	}
+	if err := b.buildPageHierarchy(); err != nil {
+		t.Fatalf("buildPageHierarchy: %v", err)
+	}
+
+	blog, ok := b.model.routeInfo("blog")
+	if !ok || blog.ListPage == nil {
+		t.Fatalf("blog route is missing its first ListPage")
+	}
+
+	page2, ok := b.model.routeInfo("blog/page/2")
+	if !ok || page2.ListPage == nil {
+		t.Fatalf("blog/page/2 is missing its ListPage")
+	}
+	if page2.ListPage.Parent() != model.Page(blog.ListPage) {
+		t.Fatalf("blog/page/2 parent = %v, want the blog section", page2.ListPage.Parent())
+	}
+}
+
+// TestBuildPageRouteNormalizesRootToHomeKind guards against a root
+// content/index.md ending up with route "/" instead of "": buildPage
+// derives route via filepath.Dir, which returns "/" for a root file,
+// and model.kindForPath only recognizes the empty string as the home
+// route.
+func TestBuildPageRouteNormalizesRootToHomeKind(t *testing.T) {
+	route := normalizeRoute(filepath.ToSlash(filepath.Dir("/index.md")))
+
+	if route != "" {
+		t.Fatalf("route = %q, want \"\" for the site root", route)
+	}
+
+	indexPage := model.NewIndexPage(route, &model.Article{ID: "index"}, nil)
+	if indexPage.Kind() != model.KindHome {
+		t.Fatalf("Kind() = %v, want %v", indexPage.Kind(), model.KindHome)
+	}
+}
@@ -0,0 +1,78 @@
+package build
+
+import (
+	"github.com/armon/go-radix"
+)
+
+// contentMap is a radix-tree-backed index of every RouteInfo in the
+// site, keyed by its full, slash-normalized route path (`blog`,
+// `blog/category-1`, the empty string for the site root, ...).
+//
+// Unlike the recursively nested map of children it replaces, a radix
+// tree supports prefix-bounded traversal (WalkPrefix) and
+// longest-prefix lookups (LongestPrefix) without having to walk the
+// entire tree, which is what lets addArticlePagesToIndexPages scale
+// with the size of the subtree it actually needs rather than the whole
+// site. It's also the foundation for a future
+// incremental rebuild mode, where only the subtrie rooted at a changed
+// file's route would need reprocessing.
+type contentMap struct {
+	tree *radix.Tree
+}
+
+// newContentMap creates an empty contentMap.
+func newContentMap() *contentMap {
+	return &contentMap{tree: radix.New()}
+}
+
+// Insert stores i under path, creating the entry if it doesn't exist
+// yet and overwriting it otherwise.
+func (c *contentMap) Insert(path string, i *RouteInfo) {
+	c.tree.Insert(path, i)
+}
+
+// Get returns the RouteInfo stored at path, if any.
+func (c *contentMap) Get(path string) (*RouteInfo, bool) {
+	v, ok := c.tree.Get(path)
+	if !ok {
+		return nil, false
+	}
+	return v.(*RouteInfo), true
+}
+
+// WalkPrefix invokes fn for every route whose path starts with prefix,
+// including prefix itself, in lexical order.
+//
+// The routes are snapshotted into a slice before fn is invoked for any
+// of them, so fn is free to insert new routes (e.g. a pagination
+// sub-route) without those insertions being picked up by the walk
+// that's still in progress.
+func (c *contentMap) WalkPrefix(prefix string, fn func(path string, i *RouteInfo)) {
+	type entry struct {
+		path string
+		info *RouteInfo
+	}
+
+	var entries []entry
+	c.tree.WalkPrefix(prefix, func(path string, v interface{}) bool {
+		entries = append(entries, entry{path: path, info: v.(*RouteInfo)})
+		return false
+	})
+
+	for _, e := range entries {
+		fn(e.path, e.info)
+	}
+}
+
+// LongestPrefix returns the RouteInfo whose key is the longest prefix
+// of path. It finds a route even when no RouteInfo was ever
+// registered for path exactly, for example when path also contains an
+// article ID. The match is on raw string prefixes, not on path
+// segments.
+func (c *contentMap) LongestPrefix(path string) (string, *RouteInfo, bool) {
+	key, v, ok := c.tree.LongestPrefix(path)
+	if !ok {
+		return "", nil, false
+	}
+	return key, v.(*RouteInfo), true
+}
@@ -0,0 +1,95 @@
+package build
+
+import "testing"
+
+func TestContentMapInsertAndGet(t *testing.T) {
+	cm := newContentMap()
+
+	blog := newRouteInfo()
+	cm.Insert("blog", blog)
+
+	got, ok := cm.Get("blog")
+	if !ok || got != blog {
+		t.Fatalf("Get(%q) = %v, %v; want %v, true", "blog", got, ok, blog)
+	}
+
+	if _, ok := cm.Get("missing"); ok {
+		t.Fatalf("Get(%q) found a route that was never inserted", "missing")
+	}
+}
+
+func TestContentMapInsertOverwrites(t *testing.T) {
+	cm := newContentMap()
+
+	first := newRouteInfo()
+	second := newRouteInfo()
+
+	cm.Insert("blog", first)
+	cm.Insert("blog", second)
+
+	got, ok := cm.Get("blog")
+	if !ok || got != second {
+		t.Fatalf("Get(%q) = %v; want the second inserted RouteInfo", "blog", got)
+	}
+}
+
+func TestContentMapWalkPrefix(t *testing.T) {
+	cm := newContentMap()
+	cm.Insert("blog", newRouteInfo())
+	cm.Insert("blog/coffee", newRouteInfo())
+	cm.Insert("docs", newRouteInfo())
+
+	var visited []string
+	cm.WalkPrefix("blog", func(path string, i *RouteInfo) {
+		visited = append(visited, path)
+	})
+
+	want := []string{"blog", "blog/coffee"}
+	if len(visited) != len(want) {
+		t.Fatalf("visited = %v, want %v", visited, want)
+	}
+	for i, path := range want {
+		if visited[i] != path {
+			t.Fatalf("visited = %v, want %v", visited, want)
+		}
+	}
+}
+
+// TestContentMapWalkPrefixSnapshotsBeforeCallback guards against the
+// tree being mutated by the walk's own callback: inserting a new route
+// from within the callback must not cause that new route to be visited
+// again in the same walk.
+func TestContentMapWalkPrefixSnapshotsBeforeCallback(t *testing.T) {
+	cm := newContentMap()
+	cm.Insert("blog", newRouteInfo())
+
+	visits := 0
+	cm.WalkPrefix("", func(path string, i *RouteInfo) {
+		visits++
+		if path == "blog" {
+			cm.Insert("blog/page/2", newRouteInfo())
+		}
+	})
+
+	if visits != 1 {
+		t.Fatalf("visits = %d, want 1 (the route inserted mid-walk must not be visited)", visits)
+	}
+}
+
+func TestContentMapLongestPrefix(t *testing.T) {
+	cm := newContentMap()
+	blog := newRouteInfo()
+	cm.Insert("blog", blog)
+
+	key, got, ok := cm.LongestPrefix("blog/coffee-roasting-basics")
+	if !ok {
+		t.Fatalf("LongestPrefix found no match")
+	}
+	if key != "blog" || got != blog {
+		t.Fatalf("LongestPrefix = %q, %v; want %q, %v", key, got, "blog", blog)
+	}
+
+	if _, _, ok := cm.LongestPrefix("docs/something"); ok {
+		t.Fatalf("LongestPrefix matched a route that shares no prefix")
+	}
+}
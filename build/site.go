@@ -4,7 +4,6 @@ package build
 
 import (
 	"github.com/dominikbraun/espresso/model"
-	"path/filepath"
 	"strings"
 )
 
@@ -12,98 +11,230 @@ import (
 // holds all components and pages and can be rendered to a static site.
 type Site struct {
 	Nav    *model.Nav
-	root   Route
+	routes *contentMap
 	Footer *model.Footer
 }
 
-// Route represents a website Route. Each Route can have multiple pages
-// associated with it, as well as multiple child routes. For example, a
-// website route like /blog/my-category can be represented as:
-//
-//	"blog" {
-//		Children:
-//			"my-category" {
-//				Pages: ...
-//			}
-//	}
-//
-// The root field of Site is considered as the root route that holds all
-// sub-routes: "/blog" would be a child route of the site's root.
-type Route struct {
-	Pages    []*model.ArticlePage
-	ListPage *model.ArticleListPage
-	Children map[string]*Route
+// RouteInfo represents a website route, keyed by its full path (e.g.
+// `blog/category-1`) in the Site's contentMap. It holds every page
+// registered under that route.
+type RouteInfo struct {
+	Pages     []*model.ArticlePage
+	IndexPage *model.IndexPage
+	ListPage  *model.ListPage
+
+	// TaxonomyPages holds, per taxonomy name (e.g. "tag"), the
+	// auto-generated taxonomy page registered for this route: either a
+	// kind page listing every term, or a term page listing every
+	// article carrying that term.
+	TaxonomyPages map[string]*model.TaxonomyPage
+
+	// OutputFormats lists the model.OutputFormat values the route's
+	// section page should be rendered to, as resolved from
+	// config.Settings.Outputs by builder.outputFormatsFor. Emitting the
+	// actual per-format files (e.g. the `.gmi` gemtext document via
+	// render/gemini.ConvertMarkdown) is the renderer's job; this field
+	// only carries the renderer's format selection through the site
+	// model.
+	OutputFormats []model.OutputFormat
 }
 
 // newSite creates and initializes a new Site instance.
 func newSite() *Site {
 	s := Site{
-		root: Route{
-			Pages:    make([]*model.ArticlePage, 0),
-			Children: make(map[string]*Route),
-		},
+		routes: newContentMap(),
 	}
 	return &s
 }
 
-// newRoute creates and initializes a new Route instance.
-func newRoute() *Route {
-	r := Route{
-		Pages: make([]*model.ArticlePage, 0),
-		ListPage: &model.ArticleListPage{
-			Page:     model.Page{},
-			Articles: make([]*model.Article, 0),
-		},
-		Children: make(map[string]*Route),
+// newRouteInfo creates and initializes a new RouteInfo instance.
+func newRouteInfo() *RouteInfo {
+	r := RouteInfo{
+		Pages:         make([]*model.ArticlePage, 0),
+		TaxonomyPages: make(map[string]*model.TaxonomyPage),
+		OutputFormats: model.DefaultOutputFormats,
 	}
 	return &r
 }
 
+// normalizeRoute strips the leading and trailing slashes from route so
+// it can be used as a contentMap key; the site root normalizes to the
+// empty string.
+func normalizeRoute(route string) string {
+	return strings.Trim(route, "/")
+}
+
 // registerPage registers a given page under the route (path) that is
-// stored in page.Path. This path must not end with a trailing slash.
+// stored in page.Path().
 //
-// If the route doesn't exist yet, all of its required child-routes will
-// be created until the entire page path is depicted.
+// If the route doesn't exist yet, it will be created.
 func (s *Site) registerPage(page *model.ArticlePage) {
-	node := &s.root
-	segments := strings.Split(page.Path, "/")
-
-	for i, seg := range segments {
-		// If the child route (identified by the segment) doesn't exist,
-		// create a new route under the current segment key.
-		if _, exists := node.Children[seg]; !exists {
-			node.Children[seg] = newRoute()
-			// Set the "absolute" path of the list page to the current route
-			// by joining all segments up to the current segment.
-			node.Children[seg].ListPage.Path = filepath.Join(segments[:i]...)
-		}
-		// Append the page to the current segment if it is the last one.
-		if i == len(segments)-1 {
-			node.Children[seg].Pages = append(node.Children[seg].Pages, page)
-			break
-		}
-		// Walk down the tree to the next segment.
-		node = node.Children[seg]
+	node := s.routeFor(page.Path())
+	node.Pages = append(node.Pages, page)
+}
+
+// registerIndexPage registers a user-provided index page under the
+// route stored in indexPage.Path().
+func (s *Site) registerIndexPage(indexPage *model.IndexPage) {
+	node := s.routeFor(indexPage.Path())
+	node.IndexPage = indexPage
+}
+
+// registerListPage registers an auto-generated list page, such as a
+// pagination page like `/blog/page/2`, under its own route.
+func (s *Site) registerListPage(route string, listPage *model.ListPage) {
+	node := s.routeFor(route)
+	node.ListPage = listPage
+}
+
+// registerTaxonomyPage registers an auto-generated taxonomy page under
+// the given route, keyed by the taxonomy it belongs to.
+func (s *Site) registerTaxonomyPage(route string, taxonomyPage *model.TaxonomyPage) {
+	node := s.routeFor(route)
+	node.TaxonomyPages[taxonomyPage.Taxonomy] = taxonomyPage
+}
+
+// routeFor returns the RouteInfo for route, creating and inserting one
+// into the contentMap if it doesn't exist yet.
+func (s *Site) routeFor(route string) *RouteInfo {
+	key := normalizeRoute(route)
+
+	if node, exists := s.routes.Get(key); exists {
+		return node
 	}
+
+	node := newRouteInfo()
+	s.routes.Insert(key, node)
+	return node
 }
 
-// WalkRoutes walks all site routes recursively and invokes a function
-// for each route. depth specifies the maximal depth that the route tree
-// will be walked down. Use -1 to walk down to the lowest level.
-func (s *Site) WalkRoutes(walkFn func(r *Route), depth int) {
-	s.walkRoute(&s.root, walkFn, depth, 0)
+// routeInfo returns the RouteInfo registered for route, if any, without
+// creating it.
+func (s *Site) routeInfo(route string) (*RouteInfo, bool) {
+	return s.routes.Get(normalizeRoute(route))
 }
 
-// walkRoute is used internally by WalkRoutes and should not be called
-// by other functions. It is the actual implementation of WalkRoutes.
-func (s *Site) walkRoute(route *Route, walkFn func(r *Route), depth int, currentDepth int) {
-	if depth != -1 && currentDepth == depth {
-		return
+// sectionPage returns the page that represents the route itself: its
+// user-provided IndexPage if there is one, otherwise its auto-generated
+// ListPage. It returns nil if neither has been built yet.
+func (i *RouteInfo) sectionPage() model.Page {
+	if i.IndexPage != nil {
+		return i.IndexPage
 	}
-	currentDepth++
+	if i.ListPage != nil {
+		return i.ListPage
+	}
+	return nil
+}
+
+// Home returns the site's home page: the section page registered for
+// the site root.
+func (s *Site) Home() model.Page {
+	root, ok := s.routeInfo("")
+	if !ok {
+		return nil
+	}
+	return root.sectionPage()
+}
 
-	for _, route := range route.Children {
-		walkFn(route)
-		s.walkRoute(route, walkFn, depth, currentDepth)
+// Sections returns every section page in the site (every route's
+// IndexPage or ListPage), excluding the home page.
+func (s *Site) Sections() []model.Page {
+	sections := make([]model.Page, 0)
+	s.WalkRoutes(func(r string, i *RouteInfo) {
+		if page := i.sectionPage(); page != nil {
+			sections = append(sections, page)
+		}
+	})
+	return sections
+}
+
+// RegularPages returns every ArticlePage registered anywhere in the
+// site.
+func (s *Site) RegularPages() []model.Page {
+	pages := make([]model.Page, 0)
+	s.WalkDescendants("", func(r string, i *RouteInfo) {
+		for _, page := range i.Pages {
+			pages = append(pages, page)
+		}
+	})
+	return pages
+}
+
+// TaxonomyPages returns every kind and term page registered for the
+// given taxonomy name (e.g. "tag").
+func (s *Site) TaxonomyPages(name string) []model.Page {
+	pages := make([]model.Page, 0)
+	s.WalkRoutes(func(r string, i *RouteInfo) {
+		if page, ok := i.TaxonomyPages[name]; ok {
+			pages = append(pages, page)
+		}
+	})
+	return pages
+}
+
+// resolveLink resolves a registered article page from an Espresso link
+// like `/coffee/coffee-roasting-basics`, trying its longest registered
+// ancestor route first, one path segment at a time, and treating
+// whatever segments remain past that route as the article ID.
+//
+// A raw contentMap.LongestPrefix lookup isn't segment-aware: it would
+// match a registered route like "coffee/coffee-roasting" as a prefix of
+// the unrelated link "coffee/coffee-roasting-basics/...", the same
+// pitfall WalkDescendants guards against for its own prefix walk.
+// Checking each segment boundary with an exact Get instead avoids it.
+func (s *Site) resolveLink(link string) (*model.ArticlePage, bool) {
+	segments := strings.Split(normalizeRoute(link), "/")
+
+	for end := len(segments) - 1; end >= 0; end-- {
+		route := strings.Join(segments[:end], "/")
+
+		node, ok := s.routes.Get(route)
+		if !ok {
+			continue
+		}
+
+		id := strings.Join(segments[end:], "/")
+		for _, page := range node.Pages {
+			if page.Article.ID == id {
+				return page, true
+			}
+		}
+		return nil, false
 	}
+
+	return nil, false
+}
+
+// WalkRoutes walks every registered route except the site root and
+// invokes walkFn for each one, passing its normalized path (e.g.
+// `blog/category-1`) and its RouteInfo.
+func (s *Site) WalkRoutes(walkFn func(r string, i *RouteInfo)) {
+	s.routes.WalkPrefix("", func(path string, i *RouteInfo) {
+		if path == "" {
+			return
+		}
+		walkFn(path, i)
+	})
+}
+
+// WalkDescendants walks route itself, if registered, and every route
+// nested below it, invoking walkFn for each one. Unlike WalkRoutes, the
+// cost is proportional to the size of the subtree rooted at route
+// rather than the whole site.
+func (s *Site) WalkDescendants(route string, walkFn func(r string, i *RouteInfo)) {
+	key := normalizeRoute(route)
+
+	s.routes.WalkPrefix(key, func(path string, i *RouteInfo) {
+		// WalkPrefix matches on the raw key, so guard against a
+		// sibling route that merely shares route as a string prefix,
+		// e.g. "blogging" when route is "blog". The site root (key ==
+		// "") has no such siblings to guard against and every
+		// registered route is one of its descendants, so the
+		// segment-boundary check only applies once key is non-empty.
+		if key != "" && path != key && !strings.HasPrefix(path, key+"/") {
+			return
+		}
+		walkFn(path, i)
+	})
 }
@@ -0,0 +1,92 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/dominikbraun/espresso/model"
+)
+
+func TestSiteWalkDescendants(t *testing.T) {
+	s := newSite()
+	s.routeFor("blog")
+	s.routeFor("blog/cat")
+	s.routeFor("docs")
+
+	tests := []struct {
+		name  string
+		route string
+		want  []string
+	}{
+		{name: "subtree", route: "blog", want: []string{"blog", "blog/cat"}},
+		{name: "leaf", route: "blog/cat", want: []string{"blog/cat"}},
+		{
+			name:  "site root visits every route",
+			route: "",
+			want:  []string{"blog", "blog/cat", "docs"},
+		},
+		{name: "unregistered route visits nothing", route: "missing", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var visited []string
+			s.WalkDescendants(tt.route, func(path string, i *RouteInfo) {
+				visited = append(visited, path)
+			})
+
+			if len(visited) != len(tt.want) {
+				t.Fatalf("visited = %v, want %v", visited, tt.want)
+			}
+			for idx, path := range tt.want {
+				if visited[idx] != path {
+					t.Fatalf("visited = %v, want %v", visited, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSiteWalkDescendantsExcludesSiblingWithSharedPrefix(t *testing.T) {
+	s := newSite()
+	s.routeFor("blog")
+	s.routeFor("blogging")
+
+	var visited []string
+	s.WalkDescendants("blog", func(path string, i *RouteInfo) {
+		visited = append(visited, path)
+	})
+
+	for _, path := range visited {
+		if path == "blogging" {
+			t.Fatalf("WalkDescendants(\"blog\") visited sibling route %q", path)
+		}
+	}
+}
+
+// TestSiteResolveLinkSegmentBoundary guards against resolveLink matching
+// a registered route that merely shares a raw string prefix with the
+// link instead of an actual path segment, e.g. "coffee/coffee-roasting"
+// swallowing part of "coffee/coffee-roasting-basics".
+func TestSiteResolveLinkSegmentBoundary(t *testing.T) {
+	s := newSite()
+	s.registerPage(model.NewArticlePage("coffee", &model.Article{ID: "coffee-roasting-basics"}, nil))
+	s.registerPage(model.NewArticlePage("coffee/coffee-roasting", &model.Article{ID: "intro"}, nil))
+
+	page, ok := s.resolveLink("/coffee/coffee-roasting-basics")
+	if !ok {
+		t.Fatalf("resolveLink did not resolve a valid link")
+	}
+	if page.Article.ID != "coffee-roasting-basics" {
+		t.Fatalf("resolved article ID = %q, want %q", page.Article.ID, "coffee-roasting-basics")
+	}
+}
+
+func TestSiteResolveLinkAtSiteRoot(t *testing.T) {
+	s := newSite()
+	s.registerPage(model.NewArticlePage("", &model.Article{ID: "about"}, nil))
+
+	page, ok := s.resolveLink("/about")
+	if !ok || page.Article.ID != "about" {
+		t.Fatalf("resolveLink(\"/about\") = %v, %v; want article %q", page, ok, "about")
+	}
+}
@@ -0,0 +1,25 @@
+package build
+
+import "testing"
+
+func TestSlugifyTerm(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{term: "Go", want: "go"},
+		{term: "Hello World", want: "hello-world"},
+		{term: "  trimmed  ", want: "trimmed"},
+		{term: "Multiple   Spaces", want: "multiple-spaces"},
+		{term: "already-kebab", want: "already-kebab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			got := slugifyTerm(tt.term)
+			if got != tt.want {
+				t.Fatalf("slugifyTerm(%q) = %q, want %q", tt.term, got, tt.want)
+			}
+		})
+	}
+}
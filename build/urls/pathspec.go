@@ -0,0 +1,156 @@
+// Package urls provides a centralized builder for the relative target
+// paths and absolute permalinks of every page Espresso generates. It
+// replaces the ad-hoc string concatenation that used to be scattered
+// across the builder and individual plugins.
+package urls
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dominikbraun/espresso/config"
+	"github.com/dominikbraun/espresso/model"
+)
+
+// PathSpec resolves the relative target path and absolute permalink of
+// a page, taking the site's URL-related settings into account. It is
+// the single source of truth for page URLs: the builder resolves every
+// page's permalink through it and stores it on the page, and plugins
+// like atom read it via model.Page.Permalink instead of assembling
+// paths themselves.
+type PathSpec struct {
+	BaseURL            string
+	UglyURLs           bool
+	DisablePathToLower bool
+	RemovePathAccents  bool
+
+	// Permalinks maps a top-level section (e.g. "blog") to a permalink
+	// pattern containing tokens `:year`, `:month`, `:section`, `:slug`
+	// and `:title`. Sections without an entry fall back to
+	// `route/:slug`.
+	Permalinks map[string]string
+}
+
+// NewPathSpec creates a PathSpec from the site settings.
+func NewPathSpec(settings config.Settings) *PathSpec {
+	return &PathSpec{
+		BaseURL:            settings.BaseURL,
+		UglyURLs:           settings.UglyURLs,
+		DisablePathToLower: settings.DisablePathToLower,
+		RemovePathAccents:  settings.RemovePathAccents,
+		Permalinks:         settings.Permalinks,
+	}
+}
+
+// PageTarget resolves the relative target path and absolute permalink
+// for an article living under route. relPath is relative to the site's
+// target directory and always uses forward slashes with a leading
+// slash, ending in a trailing slash or, if s.UglyURLs, in `.html`;
+// permalink prepends BaseURL to relPath.
+func (s *PathSpec) PageTarget(route string, article *model.Article) (relPath string, permalink string) {
+	relPath = s.relativePath(route, article.ID, article.Date)
+	permalink = s.BaseURL + relPath
+	return relPath, permalink
+}
+
+// IndexTarget resolves the relative target path and absolute permalink
+// for a route's index page (a user-provided `index.md`). Unlike
+// PageTarget, it doesn't take an article ID into account, since the
+// index page's target is the route itself rather than a sub-path of it.
+func (s *PathSpec) IndexTarget(route string) (relPath string, permalink string) {
+	relPath = s.relativePath(route, "", time.Time{})
+	permalink = s.BaseURL + relPath
+	return relPath, permalink
+}
+
+// relativePath builds the relative target path for a page living under
+// route and carrying id, applying a custom permalink pattern if one is
+// configured for the route's top-level section.
+func (s *PathSpec) relativePath(route string, id string, date time.Time) string {
+	section := strings.SplitN(strings.Trim(route, "/"), "/", 2)[0]
+
+	path := filepath.Join(route, id)
+	if pattern, ok := s.Permalinks[section]; ok {
+		path = s.expandPattern(pattern, section, id, date)
+	}
+
+	path = filepath.ToSlash(path)
+	if !s.DisablePathToLower {
+		path = strings.ToLower(path)
+	}
+	if s.RemovePathAccents {
+		path = removeAccents(path)
+	}
+	path = strings.Trim(path, "/")
+
+	return s.urlize(path)
+}
+
+// RouteTarget resolves the relative target path for a plain route that
+// isn't tied to a specific article, such as a section's root used for
+// a navigation link.
+func (s *PathSpec) RouteTarget(route string) string {
+	path := filepath.ToSlash(route)
+	if !s.DisablePathToLower {
+		path = strings.ToLower(path)
+	}
+	if s.RemovePathAccents {
+		path = removeAccents(path)
+	}
+	path = strings.Trim(path, "/")
+
+	return s.urlize(path)
+}
+
+// urlize turns an already-trimmed relative path (no leading or trailing
+// slash) into its
+// final leading-and-trailing-slash (or .html, if s.UglyURLs) form. path
+// being empty represents the site root, which urlizes to "/" (or
+// "/index.html") rather than "//".
+func (s *PathSpec) urlize(path string) string {
+	if path == "" {
+		if s.UglyURLs {
+			return "/index.html"
+		}
+		return "/"
+	}
+
+	if s.UglyURLs {
+		return "/" + path + ".html"
+	}
+
+	return "/" + path + "/"
+}
+
+// expandPattern replaces the permalink tokens `:year`, `:month`,
+// `:section`, `:slug` and `:title` in pattern with their actual values.
+// `:slug` and `:title` both expand to the article ID.
+func (s *PathSpec) expandPattern(pattern, section, id string, date time.Time) string {
+	replacer := strings.NewReplacer(
+		":year", fmt.Sprintf("%04d", date.Year()),
+		":month", fmt.Sprintf("%02d", date.Month()),
+		":section", section,
+		":slug", id,
+		":title", id,
+	)
+	return replacer.Replace(pattern)
+}
+
+// accents maps common accented runes to their unaccented equivalent.
+// It only covers the Latin characters likely to show up in a slug; it
+// is not a full Unicode normalization.
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// removeAccents strips common Latin diacritics from path.
+func removeAccents(path string) string {
+	return accents.Replace(path)
+}
@@ -0,0 +1,136 @@
+package urls
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPathSpecRelativePath(t *testing.T) {
+	date := time.Date(2023, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		spec  *PathSpec
+		route string
+		id    string
+		want  string
+	}{
+		{
+			name:  "default pattern",
+			spec:  &PathSpec{},
+			route: "blog/coffee",
+			id:    "coffee-roasting-basics",
+			want:  "/blog/coffee/coffee-roasting-basics/",
+		},
+		{
+			name:  "ugly urls",
+			spec:  &PathSpec{UglyURLs: true},
+			route: "blog",
+			id:    "post-1",
+			want:  "/blog/post-1.html",
+		},
+		{
+			name:  "lowercased by default",
+			spec:  &PathSpec{},
+			route: "Blog",
+			id:    "Post-1",
+			want:  "/blog/post-1/",
+		},
+		{
+			name:  "disable path to lower",
+			spec:  &PathSpec{DisablePathToLower: true},
+			route: "Blog",
+			id:    "Post-1",
+			want:  "/Blog/Post-1/",
+		},
+		{
+			name:  "remove path accents",
+			spec:  &PathSpec{RemovePathAccents: true},
+			route: "blog",
+			id:    "café",
+			want:  "/blog/cafe/",
+		},
+		{
+			name:  "custom permalink pattern",
+			spec:  &PathSpec{Permalinks: map[string]string{"blog": ":year/:month/:slug"}},
+			route: "blog",
+			id:    "post-1",
+			want:  "/2023/03/post-1/",
+		},
+		{
+			name:  "empty id collapses to the route itself",
+			spec:  &PathSpec{},
+			route: "blog",
+			id:    "",
+			want:  "/blog/",
+		},
+		{
+			name:  "site root with empty route and id",
+			spec:  &PathSpec{},
+			route: "",
+			id:    "",
+			want:  "/",
+		},
+		{
+			name:  "site root as a single slash",
+			spec:  &PathSpec{},
+			route: "/",
+			id:    "",
+			want:  "/",
+		},
+		{
+			name:  "site root with ugly urls",
+			spec:  &PathSpec{UglyURLs: true},
+			route: "",
+			id:    "",
+			want:  "/index.html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.spec.relativePath(tt.route, tt.id, date)
+			if got != tt.want {
+				t.Fatalf("relativePath(%q, %q) = %q, want %q", tt.route, tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathSpecIndexTargetHasNoIndexSegment(t *testing.T) {
+	spec := &PathSpec{BaseURL: "https://example.com"}
+
+	relPath, permalink := spec.IndexTarget("blog")
+
+	if relPath != "/blog/" {
+		t.Fatalf("relPath = %q, want %q", relPath, "/blog/")
+	}
+	if permalink != "https://example.com/blog/" {
+		t.Fatalf("permalink = %q, want %q", permalink, "https://example.com/blog/")
+	}
+}
+
+func TestPathSpecIndexTargetOnSiteRoot(t *testing.T) {
+	spec := &PathSpec{BaseURL: "https://example.com"}
+
+	relPath, permalink := spec.IndexTarget("/")
+
+	if relPath != "/" {
+		t.Fatalf("relPath = %q, want %q", relPath, "/")
+	}
+	if permalink != "https://example.com/" {
+		t.Fatalf("permalink = %q, want %q", permalink, "https://example.com/")
+	}
+}
+
+func TestExpandPattern(t *testing.T) {
+	spec := &PathSpec{}
+	date := time.Date(2023, time.November, 9, 0, 0, 0, 0, time.UTC)
+
+	got := spec.expandPattern(":year/:month/:section/:slug", "blog", "post-1", date)
+	want := "2023/11/blog/post-1"
+
+	if got != want {
+		t.Fatalf("expandPattern(...) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Article represents a single piece of content parsed from a Markdown
+// file, for example a blog post.
+type Article struct {
+	ID           string
+	Title        string
+	Description  string
+	Date         time.Time
+	Hide         bool
+	Related      []string
+	RelatedPages []*ArticlePage
+
+	// Taxonomies maps a taxonomy name (as declared in site.yml, e.g.
+	// "tag") to the terms the article carries under that taxonomy, as
+	// parsed from its front matter.
+	Taxonomies map[string][]string
+}
+
+// TaxonomyTerms returns the terms the article carries for the given
+// taxonomy name, or nil if the article doesn't use that taxonomy. It
+// allows templates to link back from an article to its term pages.
+func (a *Article) TaxonomyTerms(name string) []string {
+	return a.Taxonomies[name]
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// ArticlePage is a page rendered from a single Article.
+type ArticlePage struct {
+	pageMeta
+	Article *Article
+}
+
+// NewArticlePage creates an ArticlePage for article, registered under
+// path and nested below parent.
+func NewArticlePage(path string, article *Article, parent Page) *ArticlePage {
+	return &ArticlePage{
+		pageMeta: pageMeta{path: path, kind: KindArticle, parent: parent},
+		Article:  article,
+	}
+}
+
+// Title implements Page.Title.
+func (p *ArticlePage) Title() string { return p.Article.Title }
+
+// Date implements Page.Date.
+func (p *ArticlePage) Date() time.Time { return p.Article.Date }
+
+// Pages implements Page.Pages. An ArticlePage is a leaf page: it has no
+// child pages of its own.
+func (p *ArticlePage) Pages() []Page { return nil }
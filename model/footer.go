@@ -0,0 +1,13 @@
+package model
+
+// Footer represents the site's footer.
+type Footer struct {
+	Text  string
+	Items []FooterItem
+}
+
+// FooterItem is a single entry within Footer.
+type FooterItem struct {
+	Label  string
+	Target string
+}
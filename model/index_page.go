@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// IndexPage is a user-provided `index.md` page that additionally lists
+// the article pages registered under its route.
+type IndexPage struct {
+	pageMeta
+	Article      *Article
+	ArticlePages []*ArticlePage
+
+	// Paginator is non-nil if the route's full article list has been
+	// split across multiple pages, and describes this page's position
+	// within that sequence. Pages beyond the first are emitted as
+	// separate ListPage instances rather than further IndexPages.
+	Paginator *Paginator
+}
+
+// NewIndexPage creates an IndexPage for article, registered under path
+// and nested below parent. Its kind is KindHome for the site root and
+// KindSection everywhere else.
+func NewIndexPage(path string, article *Article, parent Page) *IndexPage {
+	return &IndexPage{
+		pageMeta: pageMeta{path: path, kind: kindForPath(path), parent: parent},
+		Article:  article,
+	}
+}
+
+// Title implements Page.Title.
+func (p *IndexPage) Title() string { return p.Article.Title }
+
+// Date implements Page.Date.
+func (p *IndexPage) Date() time.Time { return p.Article.Date }
+
+// Pages implements Page.Pages, returning the article pages registered
+// under the index page's route.
+func (p *IndexPage) Pages() []Page {
+	pages := make([]Page, len(p.ArticlePages))
+	for i, articlePage := range p.ArticlePages {
+		pages[i] = articlePage
+	}
+	return pages
+}
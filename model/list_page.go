@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// ListPage is an auto-generated overview page listing every
+// ArticlePage registered under a route that has no user-provided index
+// page. The list page for the site root is its home page.
+type ListPage struct {
+	pageMeta
+	ArticlePages []*ArticlePage
+
+	// Paginator is non-nil if the route's full article list has been
+	// split across multiple ListPage instances, and describes this
+	// page's position within that sequence.
+	Paginator *Paginator
+}
+
+// NewListPage creates a ListPage registered under path and nested below
+// parent. Its kind is KindHome for the site root and KindSection
+// everywhere else.
+func NewListPage(path string, parent Page) *ListPage {
+	return &ListPage{
+		pageMeta: pageMeta{path: path, kind: kindForPath(path), parent: parent},
+	}
+}
+
+// Title implements Page.Title. A ListPage has no article of its own, so
+// it returns the empty string; templates fall back to the site title.
+func (p *ListPage) Title() string { return "" }
+
+// Date implements Page.Date, returning the zero time since a ListPage
+// has no article of its own.
+func (p *ListPage) Date() time.Time { return time.Time{} }
+
+// Pages implements Page.Pages, returning the article pages registered
+// under the list page's route.
+func (p *ListPage) Pages() []Page {
+	pages := make([]Page, len(p.ArticlePages))
+	for i, articlePage := range p.ArticlePages {
+		pages[i] = articlePage
+	}
+	return pages
+}
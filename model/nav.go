@@ -0,0 +1,13 @@
+package model
+
+// Nav represents the site's top-level navigation.
+type Nav struct {
+	Brand string
+	Items []NavItem
+}
+
+// NavItem is a single entry within Nav.
+type NavItem struct {
+	Label  string
+	Target string
+}
@@ -0,0 +1,44 @@
+package model
+
+// OutputFormat describes one of the formats Espresso can render a page
+// to, such as HTML or Gemini. config.Settings.Outputs lists, per page
+// kind, which formats that kind should be rendered to; the renderer
+// then invokes a format-specific template set and writer for each one.
+type OutputFormat struct {
+	Name      string
+	MediaType string
+	Extension string
+}
+
+// HTMLFormat is the default output format every page is rendered to.
+var HTMLFormat = OutputFormat{
+	Name:      "html",
+	MediaType: "text/html",
+	Extension: ".html",
+}
+
+// GeminiFormat renders a page as a Gemini capsule document (gemtext).
+var GeminiFormat = OutputFormat{
+	Name:      "gemini",
+	MediaType: "text/gemini",
+	Extension: ".gmi",
+}
+
+// OutputFormats lists every format Espresso knows how to render a page
+// to. It's the lookup table FormatByName resolves config.Settings.Outputs
+// entries against.
+var OutputFormats = []OutputFormat{HTMLFormat, GeminiFormat}
+
+// DefaultOutputFormats is the format list a page kind falls back to when
+// config.Settings.Outputs doesn't list one explicitly.
+var DefaultOutputFormats = []OutputFormat{HTMLFormat}
+
+// FormatByName looks up one of OutputFormats by its Name (e.g. "gemini").
+func FormatByName(name string) (OutputFormat, bool) {
+	for _, f := range OutputFormats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return OutputFormat{}, false
+}
@@ -0,0 +1,95 @@
+// Package model provides the domain types that represent a built
+// Espresso site: pages, navigation, and the taxonomy/pagination
+// structures derived from them during a build.
+package model
+
+import "time"
+
+// PageKind identifies what kind of page a Page represents.
+type PageKind int
+
+const (
+	KindHome PageKind = iota
+	KindSection
+	KindArticle
+	KindTaxonomy
+	KindTerm
+)
+
+// String returns the PageKind's name, mostly useful for diagnostics.
+func (k PageKind) String() string {
+	switch k {
+	case KindHome:
+		return "home"
+	case KindSection:
+		return "section"
+	case KindArticle:
+		return "article"
+	case KindTaxonomy:
+		return "taxonomy"
+	case KindTerm:
+		return "term"
+	default:
+		return "unknown"
+	}
+}
+
+// Page is the interface implemented by every concrete page type
+// (ArticlePage, ListPage, IndexPage, TaxonomyPage). It lets plugins and
+// templates work with any page uniformly, filtering by Kind() instead
+// of requiring a dedicated hook per concrete type.
+type Page interface {
+	Kind() PageKind
+	Path() string
+	Permalink() string
+	Title() string
+	Date() time.Time
+	Pages() []Page
+	Parent() Page
+}
+
+// pageMeta holds the data shared by every concrete page type. Concrete
+// types embed it and expose it through the Page interface rather than
+// as raw fields.
+type pageMeta struct {
+	path      string
+	permalink string
+	kind      PageKind
+	parent    Page
+}
+
+// Path implements Page.Path.
+func (p pageMeta) Path() string { return p.path }
+
+// Permalink implements Page.Permalink.
+func (p pageMeta) Permalink() string { return p.permalink }
+
+// Kind implements Page.Kind.
+func (p pageMeta) Kind() PageKind { return p.kind }
+
+// Parent implements Page.Parent.
+func (p pageMeta) Parent() Page { return p.parent }
+
+// SetPermalink sets the page's permalink. It exists because the
+// permalink is only known once urls.PathSpec.PageTarget has resolved
+// it, which happens after the page itself has been constructed.
+func (p *pageMeta) SetPermalink(permalink string) {
+	p.permalink = permalink
+}
+
+// SetParent sets the page's parent. It exists because a page's parent
+// section isn't necessarily built yet at the time the page itself is
+// constructed.
+func (p *pageMeta) SetParent(parent Page) {
+	p.parent = parent
+}
+
+// kindForPath returns KindHome for the site root (an empty path) and
+// KindSection for every other route. It is shared by the page kinds
+// that are built once per route rather than once per article.
+func kindForPath(path string) PageKind {
+	if path == "" {
+		return KindHome
+	}
+	return KindSection
+}
@@ -0,0 +1,12 @@
+package model
+
+// Paginator describes a single page's position within a paginated
+// sequence of ListPage or IndexPage instances.
+type Paginator struct {
+	CurrentPage int
+	TotalPages  int
+	PrevURL     string
+	NextURL     string
+	FirstURL    string
+	LastURL     string
+}
@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+// TaxonomyPage is an auto-generated page belonging to a taxonomy, such
+// as tags or categories. It either lists every term registered for the
+// taxonomy (a "kind" page, e.g. `/tags/`, Kind() == KindTaxonomy) or
+// every article carrying a single term (a "term" page, e.g.
+// `/tags/go/`, Kind() == KindTerm), distinguished by whether Term is
+// set.
+type TaxonomyPage struct {
+	pageMeta
+	Taxonomy string
+	Term     string
+
+	// ArticlePages holds the articles carrying Term, sorted by date.
+	// It is only populated on term pages.
+	ArticlePages []*ArticlePage
+
+	// Terms holds every term registered for Taxonomy along with its
+	// article count, sorted alphabetically. It is only populated on
+	// kind pages.
+	Terms []TaxonomyTerm
+}
+
+// TaxonomyTerm describes a single term within a taxonomy's kind page.
+type TaxonomyTerm struct {
+	Term  string
+	Path  string
+	Count int
+}
+
+// NewTaxonomyPage creates a TaxonomyPage registered under path and
+// nested below parent. Its kind is KindTerm when term is set and
+// KindTaxonomy otherwise.
+func NewTaxonomyPage(path, taxonomy, term string, parent Page) *TaxonomyPage {
+	kind := KindTaxonomy
+	if term != "" {
+		kind = KindTerm
+	}
+	return &TaxonomyPage{
+		pageMeta: pageMeta{path: path, kind: kind, parent: parent},
+		Taxonomy: taxonomy,
+		Term:     term,
+	}
+}
+
+// Title implements Page.Title, returning the term for a term page or
+// the taxonomy name for its kind page.
+func (p *TaxonomyPage) Title() string {
+	if p.Term != "" {
+		return p.Term
+	}
+	return p.Taxonomy
+}
+
+// Date implements Page.Date, returning the zero time since a
+// TaxonomyPage has no article of its own.
+func (p *TaxonomyPage) Date() time.Time { return time.Time{} }
+
+// Pages implements Page.Pages, returning the article pages carrying
+// Term. It is empty for a kind page.
+func (p *TaxonomyPage) Pages() []Page {
+	pages := make([]Page, len(p.ArticlePages))
+	for i, articlePage := range p.ArticlePages {
+		pages[i] = articlePage
+	}
+	return pages
+}
@@ -3,7 +3,6 @@
 package atom
 
 import (
-	"fmt"
 	"github.com/dominikbraun/espresso/filesystem"
 	"github.com/dominikbraun/espresso/model"
 	"github.com/dominikbraun/espresso/render"
@@ -30,6 +29,13 @@ type Meta struct {
 type atom struct {
 	meta *Meta
 	feed *feeds.Feed
+
+	// seen tracks the permalinks of pages that already contributed a
+	// feed entry, so that a page rendered under multiple output formats
+	// (e.g. both model.HTMLFormat and model.GeminiFormat) still only
+	// produces a single entry. Keyed by permalink rather than article ID
+	// since two articles in different directories can share an ID.
+	seen map[string]bool
 }
 
 // New creates a new instance of the Atom plugin. It is fully initialized
@@ -47,25 +53,44 @@ func New(meta *Meta) *atom {
 			Items:       make([]*feeds.Item, 0),
 			Copyright:   meta.Copyright,
 		},
+		seen: make(map[string]bool),
 	}
 	return &a
 }
 
-// ProcessArticlePage implements render.Plugin.ProcessArticlePage and
-// generates and adds a new RSS feed entry based on the article data.
-func (a *atom) ProcessArticlePage(_ *render.Context, page *model.ArticlePage) error {
-	if page.Article.Hide {
+// ProcessPage implements render.Plugin.ProcessPage. It only reacts to
+// model.KindArticle pages, generating and adding a new RSS feed entry
+// based on the article data; every other kind (sections, taxonomies,
+// ...) is ignored since a feed only lists articles.
+func (a *atom) ProcessPage(_ *render.Context, page model.Page) error {
+	if page.Kind() != model.KindArticle {
 		return nil
 	}
 
-	absoluteURL := fmt.Sprintf("%s%s%s", a.meta.BaseURL, page.Path, page.Article.ID)
+	articlePage, ok := page.(*model.ArticlePage)
+	if !ok {
+		return nil
+	}
+
+	if articlePage.Article.Hide {
+		return nil
+	}
+
+	// Keyed by permalink rather than the bare article ID, since two
+	// articles in different directories can share the same ID (e.g.
+	// `blog/post-1.md` and `docs/post-1.md` both have ID "post-1").
+	permalink := page.Permalink()
+	if a.seen[permalink] {
+		return nil
+	}
+	a.seen[permalink] = true
 
 	item := &feeds.Item{
-		Title:       page.Article.Title,
-		Link:        &feeds.Link{Href: absoluteURL},
-		Description: page.Article.Description,
-		Id:          absoluteURL,
-		Created:     page.Article.Date,
+		Title:       articlePage.Article.Title,
+		Link:        &feeds.Link{Href: permalink},
+		Description: articlePage.Article.Description,
+		Id:          permalink,
+		Created:     articlePage.Article.Date,
 	}
 	a.feed.Items = append(a.feed.Items, item)
 
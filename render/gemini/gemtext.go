@@ -0,0 +1,99 @@
+// Package gemini converts already-parsed Markdown source into Gemini's
+// lightweight gemtext format, letting Espresso mirror its HTML site as
+// a Gemini capsule under model.GeminiFormat.
+//
+// This package only provides the Markdown-to-gemtext conversion itself.
+// Deciding which pages get a gemtext output (config.Settings.Outputs,
+// resolved per page kind by build.builder.outputFormatsFor) and writing
+// the converted document to the target directory alongside the HTML
+// output is the renderer's responsibility.
+package gemini
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+)
+
+var (
+	imagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(([^)]+)\)`)
+	linkPattern  = regexp.MustCompile(`\[([^\]]*)\]\(([^)]+)\)`)
+	listPattern  = regexp.MustCompile(`^(\s*)[-*]\s+`)
+	fencePattern = regexp.MustCompile("^```")
+)
+
+// ConvertMarkdown converts Markdown source into gemtext.
+//
+// Headings and code fences are passed through as-is since both formats
+// share the same syntax for them. List items are normalized to
+// gemtext's single `*` marker. Images are reduced to a gemtext link
+// pointing at the image, since gemtext has no inline image syntax.
+// Every other inline link is rewritten onto its own `=> url label`
+// line, directly below the line it appeared in, since gemtext has no
+// inline link syntax either.
+func ConvertMarkdown(source []byte) []byte {
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+
+	inFence := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+			out.WriteString(line)
+			out.WriteByte('\n')
+			continue
+		}
+
+		if inFence {
+			out.WriteString(line)
+			out.WriteByte('\n')
+			continue
+		}
+
+		convertLine(&out, line)
+	}
+
+	return out.Bytes()
+}
+
+// convertLine converts a single non-fenced Markdown line to gemtext,
+// appending the result (and any link lines it spins off) to out.
+func convertLine(out *bytes.Buffer, line string) {
+	var links []string
+
+	line = imagePattern.ReplaceAllStringFunc(line, func(match string) string {
+		groups := imagePattern.FindStringSubmatch(match)
+		links = append(links, formatLink(groups[2], groups[1]))
+		return ""
+	})
+
+	line = linkPattern.ReplaceAllStringFunc(line, func(match string) string {
+		groups := linkPattern.FindStringSubmatch(match)
+		links = append(links, formatLink(groups[2], groups[1]))
+		return groups[1]
+	})
+
+	if m := listPattern.FindStringSubmatch(line); m != nil {
+		line = listPattern.ReplaceAllString(line, m[1]+"* ")
+	}
+
+	out.WriteString(line)
+	out.WriteByte('\n')
+
+	for _, link := range links {
+		out.WriteString(link)
+		out.WriteByte('\n')
+	}
+}
+
+// formatLink renders a single gemtext link line. label is omitted when
+// empty, since gemtext allows a bare `=> url`.
+func formatLink(url, label string) string {
+	if label == "" {
+		return "=> " + url
+	}
+	return "=> " + url + " " + label
+}
@@ -0,0 +1,49 @@
+package gemini
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestConvertMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "heading and plain text pass through",
+			source: "# Title\nSome text.\n",
+			want:   "# Title\nSome text.\n",
+		},
+		{
+			name:   "image becomes a link line",
+			source: "![alt text](image.png)\n",
+			want:   "\n=> image.png alt text\n",
+		},
+		{
+			name:   "inline link is moved to its own line below",
+			source: "See the [docs](https://example.com/docs) for more.\n",
+			want:   "See the docs for more.\n=> https://example.com/docs docs\n",
+		},
+		{
+			name:   "list markers are normalized to *",
+			source: "- one\n* two\n",
+			want:   "* one\n* two\n",
+		},
+		{
+			name:   "code fences pass through verbatim",
+			source: "```go\n- not a list\n```\n",
+			want:   "```go\n- not a list\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertMarkdown([]byte(tt.source))
+			if !bytes.Equal(got, []byte(tt.want)) {
+				t.Fatalf("ConvertMarkdown(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}